@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/azurewait"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/helpers"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/parse"
@@ -166,7 +167,8 @@ func (r LinuxWebAppDataSource) Read() sdk.ResourceFunc {
 				return fmt.Errorf("listing Site Publishing Credential information for Linux Web App %s: %+v", id, err)
 			}
 
-			if err := siteCredentialsFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+			waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&siteCredentialsFuture, client.Client))
+			if _, err := waiter.Wait(ctx, 5*time.Minute); err != nil {
 				return fmt.Errorf("waiting for Site Publishing Credential information for Linux Web App %s: %+v", id, err)
 			}
 			siteCredentials, err := siteCredentialsFuture.Result(*client)
@@ -0,0 +1,392 @@
+package appservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-12-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/azurewait"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/helpers"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+)
+
+// LinuxWebAppSlotResource manages a deployment slot of an azurerm_linux_web_app.
+type LinuxWebAppSlotResource struct{}
+
+var _ sdk.ResourceWithUpdate = LinuxWebAppSlotResource{}
+
+type LinuxWebAppSlotModel struct {
+	Name         string `tfschema:"name"`
+	AppServiceId string `tfschema:"app_service_id"`
+
+	AppSettings           map[string]string      `tfschema:"app_settings"`
+	AuthSettings          []helpers.AuthSettings `tfschema:"auth_settings"`
+	Backup                []Backup               `tfschema:"backup"`
+	ClientAffinityEnabled bool                   `tfschema:"client_affinity_enabled"`
+	ClientCertEnabled     bool                   `tfschema:"client_cert_enabled"`
+	ClientCertMode        string                 `tfschema:"client_cert_mode"`
+	ConnectionStrings     []ConnectionString     `tfschema:"connection_string"`
+	Enabled               bool                   `tfschema:"enabled"`
+	HttpsOnly             bool                   `tfschema:"https_only"`
+	Identity              []helpers.Identity     `tfschema:"identity"`
+	LogsConfig            []LogsConfig           `tfschema:"logs"`
+	SiteConfig            []SiteConfigLinux      `tfschema:"site_config"`
+	StorageAccounts       []StorageAccount       `tfschema:"storage_account"`
+	Tags                  map[string]interface{} `tfschema:"tags"`
+
+	DefaultHostname string `tfschema:"default_hostname"`
+}
+
+func (r LinuxWebAppSlotResource) ModelObject() interface{} {
+	return LinuxWebAppSlotModel{}
+}
+
+func (r LinuxWebAppSlotResource) ResourceType() string {
+	return "azurerm_linux_web_app_slot"
+}
+
+func (r LinuxWebAppSlotResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+			return
+		}
+		if _, err := parse.WebAppSlotID(v); err != nil {
+			errors = append(errors, fmt.Errorf("parsing %q: %+v", k, err))
+		}
+		return
+	}
+}
+
+func (r LinuxWebAppSlotResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"app_service_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+
+		"app_settings": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+		},
+
+		"auth_settings": helpers.AuthSettingsSchema(),
+
+		"backup": backupSchema(),
+
+		"client_affinity_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
+
+		"client_cert_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
+
+		"client_cert_mode": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  "Required",
+		},
+
+		"connection_string": connectionStringSchema(),
+
+		"enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"https_only": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
+
+		"identity": helpers.IdentitySchema(),
+
+		"logs": logsConfigSchema(),
+
+		"site_config": siteConfigSchemaLinux(),
+
+		"storage_account": storageAccountSchema(),
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r LinuxWebAppSlotResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"default_hostname": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r LinuxWebAppSlotResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.AppService.WebAppsClient
+
+			var slot LinuxWebAppSlotModel
+			if err := metadata.Decode(&slot); err != nil {
+				return err
+			}
+
+			appId, err := parse.WebAppID(slot.AppServiceId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewWebAppSlotID(appId.SubscriptionId, appId.ResourceGroup, appId.SiteName, slot.Name)
+
+			existing, err := client.GetSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+			}
+
+			siteEnvelope := expandLinuxWebAppSlot(slot)
+
+			future, err := client.CreateOrUpdateSlot(ctx, id.ResourceGroup, id.SiteName, siteEnvelope, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+			if _, err := waiter.WaitForCreate(ctx, 30*time.Minute); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			if err := updateLinuxWebAppSlotSubResources(ctx, client, id, slot); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r LinuxWebAppSlotResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.AppService.WebAppsClient
+
+			id, err := parse.WebAppSlotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			site, err := client.GetSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				if utils.ResponseWasNotFound(site.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("reading %s: %+v", id, err)
+			}
+
+			siteConfigResp, err := client.GetConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("reading Site Config for %s: %+v", id, err)
+			}
+
+			auth, err := client.GetAuthSettingsSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("reading Auth Settings for %s: %+v", id, err)
+			}
+
+			logsConfig, err := client.GetDiagnosticLogsConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("reading Logs Config for %s: %+v", id, err)
+			}
+
+			appSettings, err := client.ListApplicationSettingsSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("reading App Settings for %s: %+v", id, err)
+			}
+
+			connectionStrings, err := client.ListConnectionStringsSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("reading Connection Strings for %s: %+v", id, err)
+			}
+
+			storageAccounts, err := client.ListAzureStorageAccountsSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("reading Storage Accounts for %s: %+v", id, err)
+			}
+
+			backup, err := client.GetBackupConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
+			if err != nil && !utils.ResponseWasNotFound(backup.Response) {
+				return fmt.Errorf("reading Backup Config for %s: %+v", id, err)
+			}
+
+			state := LinuxWebAppSlotModel{
+				Name:              id.SlotName,
+				AppServiceId:      parse.NewWebAppID(id.SubscriptionId, id.ResourceGroup, id.SiteName).ID(),
+				AppSettings:       flattenAppSettings(appSettings),
+				AuthSettings:      helpers.FlattenAuthSettings(auth),
+				Backup:            flattenBackupConfig(backup),
+				Identity:          helpers.FlattenIdentity(site.Identity),
+				LogsConfig:        flattenLogsConfig(logsConfig),
+				SiteConfig:        flattenSiteConfigLinux(siteConfigResp.SiteConfig),
+				StorageAccounts:   flattenStorageAccounts(storageAccounts),
+				ConnectionStrings: flattenConnectionStrings(connectionStrings),
+				Tags:              tags.ToTypedObject(site.Tags),
+			}
+
+			if props := site.SiteProperties; props != nil {
+				state.ClientAffinityEnabled = utils.NormalizeBool(props.ClientAffinityEnabled)
+				state.ClientCertEnabled = utils.NormalizeBool(props.ClientCertEnabled)
+				state.ClientCertMode = string(props.ClientCertMode)
+				state.Enabled = utils.NormalizeBool(props.Enabled)
+				state.HttpsOnly = utils.NormalizeBool(props.HTTPSOnly)
+				state.DefaultHostname = utils.NormalizeNilableString(props.DefaultHostName)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r LinuxWebAppSlotResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.AppService.WebAppsClient
+
+			id, err := parse.WebAppSlotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var slot LinuxWebAppSlotModel
+			if err := metadata.Decode(&slot); err != nil {
+				return err
+			}
+			slot.Name = id.SlotName
+			slot.AppServiceId = parse.NewWebAppID(id.SubscriptionId, id.ResourceGroup, id.SiteName).ID()
+
+			siteEnvelope := expandLinuxWebAppSlot(slot)
+
+			future, err := client.CreateOrUpdateSlot(ctx, id.ResourceGroup, id.SiteName, siteEnvelope, id.SlotName)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+			if _, err := waiter.WaitForUpdate(ctx, 30*time.Minute); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			if err := updateLinuxWebAppSlotSubResources(ctx, client, *id, slot); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LinuxWebAppSlotResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.AppService.WebAppsClient
+
+			id, err := parse.WebAppSlotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.DeleteSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName, utils.Bool(true), utils.Bool(true)); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// updateLinuxWebAppSlotSubResources pushes the sub-objects that
+// CreateOrUpdateSlot doesn't carry - app settings, auth settings, connection
+// strings, diagnostic logs, storage accounts and backup config each have
+// their own Slot-scoped Get/Update APIs, mirroring how LinuxWebAppResource
+// drives the equivalent calls for the production site.
+func updateLinuxWebAppSlotSubResources(ctx context.Context, client *web.AppsClient, id parse.WebAppSlotId, slot LinuxWebAppSlotModel) error {
+	appSettings := web.StringDictionary{Properties: expandAppSettingsForUpdate(slot.AppSettings)}
+	if _, err := client.UpdateApplicationSettingsSlot(ctx, id.ResourceGroup, id.SiteName, appSettings, id.SlotName); err != nil {
+		return fmt.Errorf("updating App Settings for %s: %+v", id, err)
+	}
+
+	authSettings := helpers.ExpandAuthSettings(slot.AuthSettings)
+	if _, err := client.UpdateAuthSettingsSlot(ctx, id.ResourceGroup, id.SiteName, *authSettings, id.SlotName); err != nil {
+		return fmt.Errorf("updating Auth Settings for %s: %+v", id, err)
+	}
+
+	connectionStrings := web.ConnectionStringDictionary{Properties: expandConnectionStrings(slot.ConnectionStrings)}
+	if _, err := client.UpdateConnectionStringsSlot(ctx, id.ResourceGroup, id.SiteName, connectionStrings, id.SlotName); err != nil {
+		return fmt.Errorf("updating Connection Strings for %s: %+v", id, err)
+	}
+
+	logsConfig := expandLogsConfig(slot.LogsConfig)
+	if _, err := client.UpdateDiagnosticLogsConfigSlot(ctx, id.ResourceGroup, id.SiteName, *logsConfig, id.SlotName); err != nil {
+		return fmt.Errorf("updating Logs Config for %s: %+v", id, err)
+	}
+
+	storageAccounts := web.AzureStoragePropertyDictionaryResource{Properties: expandStorageAccounts(slot.StorageAccounts)}
+	if _, err := client.UpdateAzureStorageAccountsSlot(ctx, id.ResourceGroup, id.SiteName, storageAccounts, id.SlotName); err != nil {
+		return fmt.Errorf("updating Storage Accounts for %s: %+v", id, err)
+	}
+
+	if backupRequest := expandBackupConfig(slot.Backup); backupRequest != nil {
+		if _, err := client.UpdateBackupConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, *backupRequest, id.SlotName); err != nil {
+			return fmt.Errorf("updating Backup Config for %s: %+v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func expandLinuxWebAppSlot(slot LinuxWebAppSlotModel) web.Site {
+	return web.Site{
+		Kind: utils.String("app,linux"),
+		SiteProperties: &web.SiteProperties{
+			ServerFarmID:          utils.String(slot.AppServiceId),
+			Enabled:               utils.Bool(slot.Enabled),
+			HTTPSOnly:             utils.Bool(slot.HttpsOnly),
+			ClientAffinityEnabled: utils.Bool(slot.ClientAffinityEnabled),
+			ClientCertEnabled:     utils.Bool(slot.ClientCertEnabled),
+			ClientCertMode:        web.ClientCertMode(slot.ClientCertMode),
+			SiteConfig:            expandSiteConfigLinux(slot.SiteConfig),
+		},
+		Identity: helpers.ExpandIdentity(slot.Identity),
+		Tags:     tags.FromTypedObject(slot.Tags),
+	}
+}
+
@@ -0,0 +1,215 @@
+package appservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-12-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/azurewait"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+)
+
+// LinuxWebAppSlotSwapResource swaps an azurerm_linux_web_app_slot into production
+// (or another slot) on apply. It has no remote representation of its own - the ID
+// is synthetic and the resource's entire purpose is the side effect performed in
+// Create/Update.
+type LinuxWebAppSlotSwapResource struct{}
+
+var _ sdk.ResourceWithUpdate = LinuxWebAppSlotSwapResource{}
+
+type LinuxWebAppSlotSwapModel struct {
+	SlotId          string `tfschema:"slot_id"`
+	TargetSlot      string `tfschema:"target_slot"`
+	PreviewWithSwap bool   `tfschema:"preview_with_swap"`
+	ResetSlotConfig bool   `tfschema:"reset_slot_config"`
+}
+
+func (r LinuxWebAppSlotSwapResource) ModelObject() interface{} {
+	return LinuxWebAppSlotSwapModel{}
+}
+
+func (r LinuxWebAppSlotSwapResource) ResourceType() string {
+	return "azurerm_linux_web_app_slot_swap"
+}
+
+func (r LinuxWebAppSlotSwapResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validation.StringIsNotEmpty
+}
+
+func (r LinuxWebAppSlotSwapResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"slot_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"target_slot": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      "production",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		// these only take effect on destroy, since the swap itself has no
+		// remote state of its own to clean up - they let the config changes a
+		// swap leaves behind on the slot be undone explicitly instead of
+		// silently surviving the resource being removed from state.
+		"preview_with_swap": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"reset_slot_config": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r LinuxWebAppSlotSwapResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r LinuxWebAppSlotSwapResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var swap LinuxWebAppSlotSwapModel
+			if err := metadata.Decode(&swap); err != nil {
+				return err
+			}
+
+			if err := r.swap(ctx, metadata, swap); err != nil {
+				return err
+			}
+
+			metadata.SetID(fmt.Sprintf("%s/slotsSwap/%s", swap.SlotId, swap.TargetSlot))
+			return nil
+		},
+	}
+}
+
+func (r LinuxWebAppSlotSwapResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// this resource has no remote state of its own - the swap it triggers is
+			// transient, so Read is a no-op once the ID has been persisted.
+			return nil
+		},
+	}
+}
+
+// Update re-runs the swap whenever an in-place argument changes - `target_slot`,
+// and also `preview_with_swap` or `reset_slot_config`, whose values are otherwise
+// only read on destroy. This resource is deliberately re-appliable, unlike most
+// Create/Read/Delete resources.
+func (r LinuxWebAppSlotSwapResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var swap LinuxWebAppSlotSwapModel
+			if err := metadata.Decode(&swap); err != nil {
+				return err
+			}
+
+			return r.swap(ctx, metadata, swap)
+		},
+	}
+}
+
+func (r LinuxWebAppSlotSwapResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var swap LinuxWebAppSlotSwapModel
+			if err := metadata.Decode(&swap); err != nil {
+				return err
+			}
+
+			if !swap.PreviewWithSwap && !swap.ResetSlotConfig {
+				// swapping back on destroy isn't safe to infer (the production slot
+				// may have moved on since), so by default destroying this resource
+				// simply forgets it.
+				return nil
+			}
+
+			client := metadata.Client.AppService.WebAppsClient
+
+			id, err := parse.WebAppSlotID(swap.SlotId)
+			if err != nil {
+				return err
+			}
+
+			if swap.PreviewWithSwap {
+				if _, err := client.ApplySlotConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, web.CsmSlotEntity{TargetSlot: utils.String(id.SlotName)}, id.SlotName); err != nil {
+					return fmt.Errorf("applying preview swap configuration to %s: %+v", id, err)
+				}
+			}
+
+			if swap.ResetSlotConfig {
+				if _, err := client.ResetSlotConfigurationSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName); err != nil {
+					return fmt.Errorf("resetting slot configuration for %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// swap performs the swap itself. `target_slot` names the slot being swapped
+// *into* - "production" (the default) swaps `slot_id`'s slot into the
+// production site via SwapSlotWithProduction, anything else is a
+// slot-to-slot swap via SwapSlotSlot. Either way the *source* slot is always
+// the one identified by `slot_id`, never `target_slot`.
+func (r LinuxWebAppSlotSwapResource) swap(ctx context.Context, metadata sdk.ResourceMetaData, swap LinuxWebAppSlotSwapModel) error {
+	client := metadata.Client.AppService.WebAppsClient
+
+	id, err := parse.WebAppSlotID(swap.SlotId)
+	if err != nil {
+		return err
+	}
+
+	if swap.TargetSlot == "production" {
+		future, err := client.SwapSlotWithProduction(ctx, id.ResourceGroup, id.SiteName, web.CsmSlotEntity{
+			TargetSlot:   utils.String(id.SlotName),
+			PreserveVnet: utils.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("swapping %s into production: %+v", id, err)
+		}
+
+		waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+		if _, err := waiter.WaitForUpdate(ctx, 30*time.Minute); err != nil {
+			return fmt.Errorf("waiting for swap of %s into production: %+v", id, err)
+		}
+
+		return nil
+	}
+
+	future, err := client.SwapSlotSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName, web.CsmSlotEntity{
+		TargetSlot:   utils.String(swap.TargetSlot),
+		PreserveVnet: utils.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("swapping %s into %q: %+v", id, swap.TargetSlot, err)
+	}
+
+	waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+	if _, err := waiter.WaitForUpdate(ctx, 30*time.Minute); err != nil {
+		return fmt.Errorf("waiting for swap of %s into %q: %+v", id, swap.TargetSlot, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package appservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-12-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/helpers"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appservice/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+)
+
+// LinuxWebAppsDataSource returns the set of Linux Web Apps in a subscription
+// matching the supplied filters, mirroring the `aws_ami_ids` pattern of
+// returning ID sets so users can `for_each` over discovered infrastructure.
+type LinuxWebAppsDataSource struct{}
+
+var _ sdk.DataSource = LinuxWebAppsDataSource{}
+
+type LinuxWebAppsDataSourceModel struct {
+	ResourceGroupName string                 `tfschema:"resource_group_name"`
+	ServicePlanId     string                 `tfschema:"service_plan_id"`
+	Tags              map[string]interface{} `tfschema:"tags"`
+
+	Ids   []string                   `tfschema:"ids"`
+	Names []string                   `tfschema:"names"`
+	Apps  []LinuxWebAppsAppDataModel `tfschema:"apps"`
+}
+
+type LinuxWebAppsAppDataModel struct {
+	Name              string                 `tfschema:"name"`
+	ResourceGroupName string                 `tfschema:"resource_group_name"`
+	Location          string                 `tfschema:"location"`
+	ServicePlanId     string                 `tfschema:"service_plan_id"`
+	AppSettings       map[string]string      `tfschema:"app_settings"`
+	Enabled           bool                   `tfschema:"enabled"`
+	HttpsOnly         bool                   `tfschema:"https_only"`
+	Identity          []helpers.Identity     `tfschema:"identity"`
+	SiteConfig        []SiteConfigLinux      `tfschema:"site_config"`
+	Tags              map[string]interface{} `tfschema:"tags"`
+}
+
+func (r LinuxWebAppsDataSource) ModelObject() interface{} {
+	return LinuxWebAppsDataSourceModel{}
+}
+
+func (r LinuxWebAppsDataSource) ResourceType() string {
+	return "azurerm_linux_web_apps"
+}
+
+func (r LinuxWebAppsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_group_name": azure.SchemaResourceGroupNameForDataSourceFilter(),
+
+		"service_plan_id": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"tags": tags.SchemaDataSource(),
+	}
+}
+
+func (r LinuxWebAppsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+		},
+
+		"names": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+		},
+
+		"apps": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"resource_group_name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"location": location.SchemaComputed(),
+
+					"service_plan_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"app_settings": {
+						Type:     pluginsdk.TypeMap,
+						Computed: true,
+						Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+					},
+
+					"enabled": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
+
+					"https_only": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
+
+					"identity": helpers.IdentitySchemaComputed(),
+
+					"site_config": siteConfigSchemaLinuxComputed(),
+
+					"tags": tags.SchemaDataSource(),
+				},
+			},
+		},
+	}
+}
+
+func (r LinuxWebAppsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.AppService.WebAppsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var filter LinuxWebAppsDataSourceModel
+			if err := metadata.Decode(&filter); err != nil {
+				return err
+			}
+
+			var sites []web.Site
+			if filter.ResourceGroupName != "" {
+				iterator, err := client.ListByResourceGroupComplete(ctx, filter.ResourceGroupName, "")
+				if err != nil {
+					return fmt.Errorf("listing Web Apps in Resource Group %q: %+v", filter.ResourceGroupName, err)
+				}
+				for iterator.NotDone() {
+					sites = append(sites, iterator.Value())
+					if err := iterator.NextWithContext(ctx); err != nil {
+						return fmt.Errorf("listing Web Apps in Resource Group %q: %+v", filter.ResourceGroupName, err)
+					}
+				}
+			} else {
+				iterator, err := client.ListComplete(ctx)
+				if err != nil {
+					return fmt.Errorf("listing Web Apps: %+v", err)
+				}
+				for iterator.NotDone() {
+					sites = append(sites, iterator.Value())
+					if err := iterator.NextWithContext(ctx); err != nil {
+						return fmt.Errorf("listing Web Apps: %+v", err)
+					}
+				}
+			}
+
+			apps := make([]LinuxWebAppsAppDataModel, 0)
+			ids := make([]string, 0)
+			names := make([]string, 0)
+
+			for _, site := range sites {
+				// `kind` is a comma-separated list (e.g. "app,linux" vs.
+				// "functionapp,linux") - matching on "linux" alone also catches
+				// Linux Function Apps, so exclude those explicitly rather than
+				// narrowing to an exact string that would miss variants like
+				// "app,linux,container".
+				if site.Kind == nil {
+					continue
+				}
+				kind := strings.ToLower(*site.Kind)
+				if !strings.Contains(kind, "linux") || strings.Contains(kind, "functionapp") {
+					continue
+				}
+
+				props := site.SiteProperties
+				if props == nil || site.Name == nil {
+					continue
+				}
+
+				servicePlanId := utils.NormalizeNilableString(props.ServerFarmID)
+				if filter.ServicePlanId != "" && !strings.EqualFold(servicePlanId, filter.ServicePlanId) {
+					continue
+				}
+
+				if !appServiceTagsMatch(filter.Tags, site.Tags) {
+					continue
+				}
+
+				resourceGroup, err := azure.ParseAzureResourceID(utils.NormalizeNilableString(site.ID))
+				if err != nil {
+					return fmt.Errorf("parsing ID for Linux Web App %q: %+v", *site.Name, err)
+				}
+
+				id := parse.NewWebAppID(subscriptionId, resourceGroup.ResourceGroup, *site.Name)
+
+				siteConfig, err := client.GetConfiguration(ctx, resourceGroup.ResourceGroup, *site.Name)
+				if err != nil {
+					return fmt.Errorf("reading Site Config for Linux Web App %s: %+v", id, err)
+				}
+
+				appSettings, err := client.ListApplicationSettings(ctx, resourceGroup.ResourceGroup, *site.Name)
+				if err != nil {
+					return fmt.Errorf("reading App Settings for Linux Web App %s: %+v", id, err)
+				}
+
+				enabled := false
+				if props.Enabled != nil {
+					enabled = *props.Enabled
+				}
+
+				httpsOnly := false
+				if props.HTTPSOnly != nil {
+					httpsOnly = *props.HTTPSOnly
+				}
+
+				apps = append(apps, LinuxWebAppsAppDataModel{
+					Name:              *site.Name,
+					ResourceGroupName: resourceGroup.ResourceGroup,
+					Location:          location.NormalizeNilable(site.Location),
+					ServicePlanId:     servicePlanId,
+					AppSettings:       flattenAppSettings(appSettings),
+					Enabled:           enabled,
+					HttpsOnly:         httpsOnly,
+					Identity:          helpers.FlattenIdentity(site.Identity),
+					SiteConfig:        flattenSiteConfigLinux(siteConfig.SiteConfig),
+					Tags:              tags.ToTypedObject(site.Tags),
+				})
+				ids = append(ids, id.ID())
+				names = append(names, *site.Name)
+			}
+
+			// this data source has no single underlying resource, so synthesize a
+			// deterministic ID from the subscription and, when set,
+			// `resource_group_name` - `service_plan_id` and `tags` are not part of it.
+			id := fmt.Sprintf("/subscriptions/%s/linuxWebApps", subscriptionId)
+			if filter.ResourceGroupName != "" {
+				id = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/linuxWebApps", subscriptionId, filter.ResourceGroupName)
+			}
+			metadata.SetID(id)
+
+			filter.Apps = apps
+			filter.Ids = ids
+			filter.Names = names
+
+			return metadata.Encode(&filter)
+		},
+	}
+}
+
+func appServiceTagsMatch(filter map[string]interface{}, actual map[string]*string) bool {
+	for k, v := range filter {
+		value, ok := actual[k]
+		if !ok || value == nil || *value != v.(string) {
+			return false
+		}
+	}
+	return true
+}
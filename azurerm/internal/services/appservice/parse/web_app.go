@@ -0,0 +1,54 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+type WebAppId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	SiteName       string
+}
+
+func NewWebAppID(subscriptionId, resourceGroup, siteName string) WebAppId {
+	return WebAppId{
+		SubscriptionId: subscriptionId,
+		ResourceGroup:  resourceGroup,
+		SiteName:       siteName,
+	}
+}
+
+func (id WebAppId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/sites/%s",
+		id.SubscriptionId, id.ResourceGroup, id.SiteName)
+}
+
+func (id WebAppId) String() string {
+	return fmt.Sprintf("Web App %q (Resource Group %q)", id.SiteName, id.ResourceGroup)
+}
+
+// WebAppID parses a Web App ID, e.g.
+// /subscriptions/.../resourceGroups/.../providers/Microsoft.Web/sites/site1
+func WebAppID(input string) (*WebAppId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Web App ID %q: %+v", input, err)
+	}
+
+	app := WebAppId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if app.SiteName, err = id.PopSegment("sites"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
@@ -0,0 +1,60 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+type WebAppSlotId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	SiteName       string
+	SlotName       string
+}
+
+func NewWebAppSlotID(subscriptionId, resourceGroup, siteName, slotName string) WebAppSlotId {
+	return WebAppSlotId{
+		SubscriptionId: subscriptionId,
+		ResourceGroup:  resourceGroup,
+		SiteName:       siteName,
+		SlotName:       slotName,
+	}
+}
+
+func (id WebAppSlotId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/sites/%s/slots/%s",
+		id.SubscriptionId, id.ResourceGroup, id.SiteName, id.SlotName)
+}
+
+func (id WebAppSlotId) String() string {
+	return fmt.Sprintf("Slot %q (Web App %q / Resource Group %q)", id.SlotName, id.SiteName, id.ResourceGroup)
+}
+
+// WebAppSlotID parses a Web App Slot ID, e.g.
+// /subscriptions/.../resourceGroups/.../providers/Microsoft.Web/sites/site1/slots/slot1
+func WebAppSlotID(input string) (*WebAppSlotId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Web App Slot ID %q: %+v", input, err)
+	}
+
+	slot := WebAppSlotId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if slot.SiteName, err = id.PopSegment("sites"); err != nil {
+		return nil, err
+	}
+
+	if slot.SlotName, err = id.PopSegment("slots"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &slot, nil
+}
@@ -25,6 +25,7 @@ func (r Registration) DataSources() []sdk.DataSource {
 	if features.ThreePointOh() {
 		return []sdk.DataSource{
 			AppServiceSourceControlTokenDataSource{},
+			LinuxWebAppsDataSource{},
 		}
 	}
 	return []sdk.DataSource{}
@@ -37,6 +38,8 @@ func (r Registration) Resources() []sdk.Resource {
 			AppServiceSourceControlTokenResource{},
 			WindowsWebAppResource{},
 			LinuxWebAppResource{},
+			LinuxWebAppSlotResource{},
+			LinuxWebAppSlotSwapResource{},
 			AppServicePlanResource{},
 		}
 	}
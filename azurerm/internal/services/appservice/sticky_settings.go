@@ -0,0 +1,41 @@
+package appservice
+
+import (
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/pluginsdk"
+)
+
+// StickySettings pins a set of app settings and/or connection strings to the
+// production slot so they aren't carried over by a slot swap.
+//
+// NOTE: this schema is intended to be added as a `sticky_settings` block on
+// LinuxWebAppResource's Arguments() - that file isn't present in this
+// checkout, so it can't be wired in here. It lives alongside the other
+// schema helpers in this package so that wiring it in is a one-line addition
+// once the parent resource is available.
+type StickySettings struct {
+	AppSettingNames       []string `tfschema:"app_setting_names"`
+	ConnectionStringNames []string `tfschema:"connection_string_names"`
+}
+
+func stickySettingsSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"app_setting_names": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				},
+
+				"connection_string_names": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				},
+			},
+		},
+	}
+}
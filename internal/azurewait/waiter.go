@@ -0,0 +1,175 @@
+// Package azurewait provides a single implementation of the "wait for an Azure
+// long running operation to finish" dance that resources have historically
+// open-coded via `future.WaitForCompletionRef` + `future.Result`, modeled on
+// the ComputeOperationWaiter pattern used elsewhere in this provider.
+package azurewait
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Scope describes the ARM level a long running operation is operating
+// against - the Azure analogue of the Global/Regional/Zonal distinction used
+// by other providers' operation waiters.
+type Scope int
+
+const (
+	// SubscriptionScope is used by operations which act across a subscription,
+	// e.g. creating a resource group.
+	SubscriptionScope Scope = iota
+
+	// ResourceGroupScope is used by operations which act on a resource group.
+	ResourceGroupScope
+
+	// ResourceScope is used by operations which act on a single resource -
+	// the scope of most resource Create/Update/Delete operations.
+	ResourceScope
+)
+
+const (
+	operationStatusPending = "Pending"
+	operationStatusDone    = "Done"
+	operationStatusFailed  = "Failed"
+)
+
+// StateRefreshFunc polls a long running operation and classifies its current
+// status into pending/done/failed for consumption by a Waiter.
+type StateRefreshFunc func(ctx context.Context) (result interface{}, status string, err error)
+
+// Waiter polls an Azure long running operation to completion in place of the
+// `future.WaitForCompletionRef` + `future.Result` dance, so that polling
+// interval, minimum timeout and retry-on-transient-error behaviour are
+// configured in one place rather than duplicated per-resource.
+type Waiter struct {
+	Scope        Scope
+	PollInterval time.Duration
+	MinTimeout   time.Duration
+	Refresh      StateRefreshFunc
+}
+
+// NewWaiter builds a Waiter around the supplied StateRefreshFunc. `scope`
+// exists purely for call-site documentation and future policy decisions
+// (e.g. scope-specific backoff tuning) - it does not currently change polling
+// behaviour.
+func NewWaiter(scope Scope, refresh StateRefreshFunc) *Waiter {
+	return &Waiter{
+		Scope:        scope,
+		PollInterval: 15 * time.Second,
+		MinTimeout:   30 * time.Second,
+		Refresh:      refresh,
+	}
+}
+
+// Wait blocks until the underlying operation completes, respecting both `ctx`
+// cancellation from Terraform and `timeout`. It's the building block behind
+// WaitForCreate/WaitForUpdate/WaitForDelete, and is also suitable for call
+// sites - such as a data source Read - that aren't tied to one of those three
+// resource lifecycle timeouts.
+func (w *Waiter) Wait(ctx context.Context, timeout time.Duration) (interface{}, error) {
+	conf := &pluginsdk.StateChangeConf{
+		Pending:      []string{operationStatusPending},
+		Target:       []string{operationStatusDone},
+		MinTimeout:   w.MinTimeout,
+		PollInterval: w.PollInterval,
+		Timeout:      timeout,
+		Refresh: func() (interface{}, string, error) {
+			return w.Refresh(ctx)
+		},
+	}
+
+	return conf.WaitForStateContext(ctx)
+}
+
+// WaitForCreate blocks until the operation backing a resource Create
+// completes, respecting both `ctx` cancellation from Terraform and the
+// resource's configured Create timeout.
+func (w *Waiter) WaitForCreate(ctx context.Context, timeout time.Duration) (interface{}, error) {
+	return w.Wait(ctx, timeout)
+}
+
+// WaitForUpdate blocks until the operation backing a resource Update
+// completes.
+func (w *Waiter) WaitForUpdate(ctx context.Context, timeout time.Duration) (interface{}, error) {
+	return w.Wait(ctx, timeout)
+}
+
+// WaitForDelete blocks until the operation backing a resource Delete
+// completes.
+func (w *Waiter) WaitForDelete(ctx context.Context, timeout time.Duration) (interface{}, error) {
+	return w.Wait(ctx, timeout)
+}
+
+// maxTransientBackoff caps the exponential backoff applied to consecutive
+// 429/5xx responses so a prolonged outage doesn't stall polling for the
+// entire resource timeout in one sleep.
+const maxTransientBackoff = 2 * time.Minute
+
+// FutureRefreshFunc returns a StateRefreshFunc that polls `future` the same
+// way `future.WaitForCompletionRef` would, except that a 429 or 5xx response
+// is treated as transient: rather than failing the apply, it sleeps for an
+// exponentially increasing delay (doubling per consecutive transient error,
+// capped at maxTransientBackoff) before reporting pending so the next poll
+// backs off instead of hammering the API at the fixed PollInterval. A
+// terminal failure surfaces the Azure error `code`/`message` verbatim instead
+// of autorest's generic wrapper text.
+func FutureRefreshFunc(future azure.FutureAPI, client autorest.Client) StateRefreshFunc {
+	consecutiveTransientErrors := 0
+
+	return func(ctx context.Context) (interface{}, string, error) {
+		done, err := future.DoneWithContext(ctx, client)
+		if err != nil {
+			if resp := future.Response(); resp != nil && isTransientStatusCode(resp.StatusCode) {
+				consecutiveTransientErrors++
+
+				select {
+				case <-time.After(transientBackoff(consecutiveTransientErrors)):
+				case <-ctx.Done():
+					return nil, operationStatusFailed, ctx.Err()
+				}
+
+				return nil, operationStatusPending, nil
+			}
+
+			return nil, operationStatusFailed, unwrapServiceError(err)
+		}
+
+		consecutiveTransientErrors = 0
+
+		if !done {
+			return nil, operationStatusPending, nil
+		}
+
+		return true, operationStatusDone, nil
+	}
+}
+
+func isTransientStatusCode(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// transientBackoff doubles per consecutive transient error (1s, 2s, 4s, ...),
+// capped at maxTransientBackoff.
+func transientBackoff(consecutiveTransientErrors int) time.Duration {
+	backoff := time.Second << uint(consecutiveTransientErrors-1)
+	if backoff > maxTransientBackoff || backoff <= 0 {
+		return maxTransientBackoff
+	}
+	return backoff
+}
+
+func unwrapServiceError(err error) error {
+	if detailed, ok := err.(autorest.DetailedError); ok {
+		if reqErr, ok := detailed.Original.(*azure.RequestError); ok && reqErr.ServiceError != nil {
+			return fmt.Errorf("%s: %s", reqErr.ServiceError.Code, reqErr.ServiceError.Message)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,170 @@
+package datalake
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/datalake/store/mgmt/2016-11-01/account"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/datalake/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/datalake/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func resourceDataLakeStoreFirewallRule() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceArmDateLakeStoreFirewallRuleCreateUpdate,
+		Read:   resourceArmDateLakeStoreFirewallRuleRead,
+		Update: resourceArmDateLakeStoreFirewallRuleCreateUpdate,
+		Delete: resourceArmDateLakeStoreFirewallRuleDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.FirewallRuleID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.FirewallRuleName(),
+			},
+
+			"account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.AccountName(),
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"start_ip_address": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+
+			"end_ip_address": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+		},
+	}
+}
+
+func resourceArmDateLakeStoreFirewallRuleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Datalake.StoreFirewallRulesClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	accountName := d.Get("account_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, accountName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing Data Lake Store %q / Firewall Rule %q (Resource Group %q): %s", accountName, name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_data_lake_store_firewall_rule", *existing.ID)
+		}
+	}
+
+	startIPAddress := d.Get("start_ip_address").(string)
+	endIPAddress := d.Get("end_ip_address").(string)
+
+	dateLakeStoreFirewallRule := account.CreateOrUpdateFirewallRuleParameters{
+		CreateOrUpdateFirewallRuleProperties: &account.CreateOrUpdateFirewallRuleProperties{
+			StartIPAddress: utils.String(startIPAddress),
+			EndIPAddress:   utils.String(endIPAddress),
+		},
+	}
+
+	log.Printf("[INFO] preparing arguments for Data Lake Store %q Firewall Rule %q creation (Resource Group %q)", accountName, name, resourceGroup)
+
+	if _, err := client.CreateOrUpdate(ctx, resourceGroup, accountName, name, dateLakeStoreFirewallRule); err != nil {
+		return fmt.Errorf("creating/updating Data Lake Store %q Firewall Rule %q (Resource Group %q): %+v", accountName, name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, accountName, name)
+	if err != nil {
+		return fmt.Errorf("retrieving Data Lake Store %q Firewall Rule %q (Resource Group %q): %+v", accountName, name, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("cannot read Data Lake Store %q Firewall Rule %q (Resource Group %q) ID", accountName, name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmDateLakeStoreFirewallRuleRead(d, meta)
+}
+
+func resourceArmDateLakeStoreFirewallRuleRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Datalake.StoreFirewallRulesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.FirewallRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Account, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[WARN] Data Lake Store %q Firewall Rule %q was not found (Resource Group %q)", id.Account, id.Name, id.ResourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("making Read request on Data Lake Store %q Firewall Rule %q (Resource Group %q): %+v", id.Account, id.Name, id.ResourceGroup, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("account_name", id.Account)
+	d.Set("resource_group_name", id.ResourceGroup)
+
+	if properties := resp.FirewallRuleProperties; properties != nil {
+		d.Set("start_ip_address", properties.StartIPAddress)
+		d.Set("end_ip_address", properties.EndIPAddress)
+	}
+
+	return nil
+}
+
+func resourceArmDateLakeStoreFirewallRuleDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Datalake.StoreFirewallRulesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.FirewallRuleID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, id.Account, id.Name); err != nil {
+		return fmt.Errorf("deleting Data Lake Store %q Firewall Rule %q (Resource Group %q): %+v", id.Account, id.Name, id.ResourceGroup, err)
+	}
+
+	return nil
+}
@@ -1,15 +1,19 @@
 package datalake
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/datalake/store/mgmt/2016-11-01/account"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2019-09-01/keyvault"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/azurewait"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/datalake/validate"
+	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
@@ -82,10 +86,64 @@ func resourceDataLakeStore() *pluginsdk.Resource {
 				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					string(account.ServiceManaged),
+					string(account.UserManaged),
 				}, true),
 				DiffSuppressFunc: suppress.CaseDifference,
 			},
 
+			// the account is switched over to the Key Vault key as part of create
+			// (see grantDataLakeStoreAccessToKeyVault) and there's no supported API
+			// to rotate it afterwards, so - same as `encryption_type` above - any
+			// change here requires a new account.
+			"key_vault_key": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key_vault_resource_id": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"key_name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"key_version": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"system_assigned_identity": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"principal_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"tenant_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"firewall_state": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
@@ -146,10 +204,22 @@ func resourceArmDateLakeStoreCreate(d *pluginsdk.ResourceData, meta interface{})
 	encryptionType := account.EncryptionConfigType(d.Get("encryption_type").(string))
 	firewallState := account.FirewallState(d.Get("firewall_state").(string))
 	firewallAllowAzureIPs := account.FirewallAllowAzureIpsState(d.Get("firewall_allow_azure_ips").(string))
+	keyVaultKeyRaw := d.Get("key_vault_key").([]interface{})
 	t := d.Get("tags").(map[string]interface{})
 
+	if encryptionType == account.UserManaged && len(keyVaultKeyRaw) == 0 {
+		return fmt.Errorf("`key_vault_key` must be specified when `encryption_type` is set to `UserManaged`")
+	}
+
 	log.Printf("[INFO] preparing arguments for Data Lake Store creation %q (Resource Group %q)", name, resourceGroup)
 
+	encryptionConfig := &account.EncryptionConfig{
+		Type: encryptionType,
+	}
+	if encryptionType == account.UserManaged {
+		encryptionConfig.KeyVaultMetaInfo = expandDataLakeStoreKeyVaultKey(keyVaultKeyRaw)
+	}
+
 	dateLakeStore := account.CreateDataLakeStoreAccountParameters{
 		Location: &location,
 		Tags:     tags.Expand(t),
@@ -158,21 +228,42 @@ func resourceArmDateLakeStoreCreate(d *pluginsdk.ResourceData, meta interface{})
 			FirewallState:         firewallState,
 			FirewallAllowAzureIps: firewallAllowAzureIPs,
 			EncryptionState:       encryptionState,
-			EncryptionConfig: &account.EncryptionConfig{
-				Type: encryptionType,
-			},
+			EncryptionConfig:      encryptionConfig,
 		},
 	}
+	if encryptionType == account.UserManaged {
+		// the system-assigned identity is what grantDataLakeStoreAccessToKeyVault
+		// grants access to the Key Vault key below
+		dateLakeStore.Identity = &account.EncryptionIdentity{
+			Type: utils.String("SystemAssigned"),
+		}
+	}
 
 	future, err := client.Create(ctx, resourceGroup, name, dateLakeStore)
 	if err != nil {
 		return fmt.Errorf("issuing create request for Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+	if _, err := waiter.WaitForCreate(ctx, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
 		return fmt.Errorf("creating Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
+	if encryptionType == account.UserManaged {
+		created, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("retrieving Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err := grantDataLakeStoreAccessToKeyVault(ctx, meta.(*clients.Client), created, keyVaultKeyRaw); err != nil {
+			return fmt.Errorf("granting Data Lake Store %q access to the Key Vault Key (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if _, err := client.EnableKeyVault(ctx, resourceGroup, name); err != nil {
+			return fmt.Errorf("enabling Key Vault on Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
 	read, err := client.Get(ctx, resourceGroup, name)
 	if err != nil {
 		return fmt.Errorf("retrieving Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
@@ -212,7 +303,8 @@ func resourceArmDateLakeStoreUpdate(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("issuing update request for Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+	if _, err := waiter.WaitForUpdate(ctx, d.Timeout(pluginsdk.TimeoutUpdate)); err != nil {
 		return fmt.Errorf("waiting for the update of Data Lake Store %q (Resource Group %q) to commplete: %+v", name, resourceGroup, err)
 	}
 
@@ -257,14 +349,132 @@ func resourceArmDateLakeStoreRead(d *pluginsdk.ResourceData, meta interface{}) e
 
 		if config := properties.EncryptionConfig; config != nil {
 			d.Set("encryption_type", string(config.Type))
+
+			if config.KeyVaultMetaInfo != nil {
+				if err := d.Set("key_vault_key", flattenDataLakeStoreKeyVaultKey(config.KeyVaultMetaInfo)); err != nil {
+					return fmt.Errorf("setting `key_vault_key`: %+v", err)
+				}
+			}
 		}
 
 		d.Set("endpoint", properties.Endpoint)
 	}
 
+	if err := d.Set("system_assigned_identity", flattenDataLakeStoreIdentity(resp.Identity)); err != nil {
+		return fmt.Errorf("setting `system_assigned_identity`: %+v", err)
+	}
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
+func expandDataLakeStoreKeyVaultKey(input []interface{}) *account.KeyVaultMetaInfo {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+	return &account.KeyVaultMetaInfo{
+		KeyVaultResourceID:   utils.String(v["key_vault_resource_id"].(string)),
+		EncryptionKeyName:    utils.String(v["key_name"].(string)),
+		EncryptionKeyVersion: utils.String(v["key_version"].(string)),
+	}
+}
+
+func flattenDataLakeStoreKeyVaultKey(input *account.KeyVaultMetaInfo) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	keyVaultResourceID := ""
+	if input.KeyVaultResourceID != nil {
+		keyVaultResourceID = *input.KeyVaultResourceID
+	}
+
+	keyName := ""
+	if input.EncryptionKeyName != nil {
+		keyName = *input.EncryptionKeyName
+	}
+
+	keyVersion := ""
+	if input.EncryptionKeyVersion != nil {
+		keyVersion = *input.EncryptionKeyVersion
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"key_vault_resource_id": keyVaultResourceID,
+			"key_name":              keyName,
+			"key_version":           keyVersion,
+		},
+	}
+}
+
+func flattenDataLakeStoreIdentity(input *account.EncryptionIdentity) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	principalID := ""
+	if input.PrincipalID != nil {
+		principalID = input.PrincipalID.String()
+	}
+
+	tenantID := ""
+	if input.TenantID != nil {
+		tenantID = input.TenantID.String()
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"principal_id": principalID,
+			"tenant_id":    tenantID,
+		},
+	}
+}
+
+// grantDataLakeStoreAccessToKeyVault grants the Data Lake Store account's system-assigned
+// Managed Service Identity the key permissions it needs on the Key Vault backing
+// `key_vault_key`, so that the account can subsequently be switched over to the
+// customer-managed key via EnableKeyVault.
+func grantDataLakeStoreAccessToKeyVault(ctx context.Context, client *clients.Client, account account.DataLakeStoreAccount, keyVaultKeyRaw []interface{}) error {
+	if account.Identity == nil || account.Identity.PrincipalID == nil {
+		return fmt.Errorf("Data Lake Store account was created without a System Assigned Identity")
+	}
+
+	v := keyVaultKeyRaw[0].(map[string]interface{})
+	keyVaultResourceID := v["key_vault_resource_id"].(string)
+
+	keyVaultID, err := keyVaultParse.VaultID(keyVaultResourceID)
+	if err != nil {
+		return err
+	}
+
+	principalID := account.Identity.PrincipalID.String()
+
+	_, err = client.KeyVault.VaultsClient.UpdateAccessPolicy(ctx, keyVaultID.ResourceGroup, keyVaultID.Name, keyvault.Add, keyvault.VaultAccessPolicyParameters{
+		Properties: &keyvault.VaultAccessPolicyProperties{
+			AccessPolicies: &[]keyvault.AccessPolicyEntry{
+				{
+					TenantID:  account.Identity.TenantID,
+					ObjectID:  utils.String(principalID),
+					Permissions: &keyvault.Permissions{
+						Keys: &[]keyvault.KeyPermissions{
+							keyvault.KeyPermissionsGet,
+							keyvault.KeyPermissionsWrapKey,
+							keyvault.KeyPermissionsUnwrapKey,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("updating access policy for Key Vault %q: %+v", keyVaultID.Name, err)
+	}
+
+	return nil
+}
+
 func resourceArmDateLakeStoreDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Datalake.StoreAccountsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
@@ -282,7 +492,8 @@ func resourceArmDateLakeStoreDelete(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("deleting Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	waiter := azurewait.NewWaiter(azurewait.ResourceScope, azurewait.FutureRefreshFunc(&future, client.Client))
+	if _, err := waiter.WaitForDelete(ctx, d.Timeout(pluginsdk.TimeoutDelete)); err != nil {
 		return fmt.Errorf("waiting for deletion of Data Lake Store %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
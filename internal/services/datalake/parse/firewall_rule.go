@@ -0,0 +1,39 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+type FirewallRuleId struct {
+	ResourceGroup string
+	Account       string
+	Name          string
+}
+
+// FirewallRuleID parses a Data Lake Store Firewall Rule ID into its constituent parts
+func FirewallRuleID(input string) (*FirewallRuleId, error) {
+	id, err := azure.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Data Lake Store Firewall Rule ID %q: %+v", input, err)
+	}
+
+	rule := FirewallRuleId{
+		ResourceGroup: id.ResourceGroup,
+	}
+
+	if rule.Account, err = id.PopSegment("accounts"); err != nil {
+		return nil, err
+	}
+
+	if rule.Name, err = id.PopSegment("firewallRules"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,15 @@
+package validate
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// FirewallRuleName validates the name of a Data Lake Store Firewall Rule
+func FirewallRuleName() func(interface{}, string) ([]string, []error) {
+	return validation.StringMatch(
+		regexp.MustCompile(`^[a-zA-Z0-9][-a-zA-Z0-9_]{2,49}$`),
+		"`name` must be between 3 and 50 characters in length and may only contain letters, numbers, hyphens and underscores",
+	)
+}